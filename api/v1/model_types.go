@@ -0,0 +1,243 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModelSpec defines the desired state of Model
+type ModelSpec struct {
+	// Image is the Ollama model tag to pull and serve, e.g. "llama2:7b".
+	Image string `json:"image"`
+
+	// Replicas is the desired number of model server replicas. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// StorageClassName is the storage class used for the shared image store
+	// PVC that caches pulled model blobs.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// PersistentVolumeClaim overrides the shared image store PVC spec.
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimSpec `json:"persistentVolumeClaim,omitempty"`
+
+	// PersistentVolume overrides the shared image store PV spec.
+	// +optional
+	PersistentVolume *corev1.PersistentVolumeSpec `json:"persistentVolume,omitempty"`
+
+	// WorkloadStrategy selects the workload kind used to run the model
+	// server. Defaults to Deployment. StatefulSet gives each replica a
+	// stable identity and its own PVC, so model weights survive restarts
+	// instead of being re-pulled by the init container every time.
+	// +optional
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet
+	// +kubebuilder:default=Deployment
+	WorkloadStrategy WorkloadStrategyType `json:"workloadStrategy,omitempty"`
+
+	// InPlaceUpdate, when true, skips pod recreation for image updates that
+	// only change the served Ollama model tag: instead of rolling the
+	// workload, the operator execs `ollama pull` against the running pods
+	// and tracks the change via an annotation-based revision marker. Has no
+	// effect on changes to the container image itself.
+	// +optional
+	InPlaceUpdate bool `json:"inPlaceUpdate,omitempty"`
+
+	// Resources are the compute resource requests and limits for the Ollama
+	// server container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector constrains the model server pods to nodes with matching labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the model server pods to schedule onto nodes with matching taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity constrains pod scheduling via node/pod affinity and anti-affinity rules.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// RuntimeClassName selects the container runtime (e.g. "nvidia") used to run the model server pods.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// GPU is a convenience accelerator request that expands into the matching
+	// resource request and toleration for the given vendor, so users don't
+	// have to hand-write nvidia.com/gpu plumbing themselves. It adds no node
+	// selector; use NodeSelector for that.
+	// +optional
+	GPU *GPUSpec `json:"gpu,omitempty"`
+
+	// Autoscaling, when set, backs the model server workload with a
+	// HorizontalPodAutoscaler driven by Ollama's own inference queue
+	// metrics instead of a static Replicas count.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+}
+
+// AutoscalingSpec configures a HorizontalPodAutoscaler for the model server workload.
+type AutoscalingSpec struct {
+	// MinReplicas is the lower replica bound. Defaults to 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper replica bound.
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetConcurrentRequests is the target average number of concurrent
+	// inference requests per replica. Defaults to 10.
+	// +optional
+	TargetConcurrentRequests *int32 `json:"targetConcurrentRequests,omitempty"`
+	// MetricName overrides the metric the HPA scales on. Defaults to
+	// "ollama_active_requests", published by the metrics-exporter sidecar
+	// from Ollama's /api/ps endpoint.
+	// +optional
+	MetricName string `json:"metricName,omitempty"`
+}
+
+// GPUSpec requests accelerator capacity for the model server pods.
+type GPUSpec struct {
+	// Vendor is the accelerator vendor, used to pick the resource name
+	// (e.g. "nvidia.com/gpu") and the matching toleration.
+	// +kubebuilder:validation:Enum=nvidia;amd;intel
+	Vendor string `json:"vendor"`
+	// Count is the number of accelerator devices to request per replica.
+	// +kubebuilder:default=1
+	Count int32 `json:"count,omitempty"`
+}
+
+// WorkloadStrategyType identifies which Kubernetes workload kind backs a Model.
+type WorkloadStrategyType string
+
+const (
+	// WorkloadStrategyDeployment runs the model server as a Deployment. This is the default.
+	WorkloadStrategyDeployment WorkloadStrategyType = "Deployment"
+	// WorkloadStrategyStatefulSet runs the model server as a StatefulSet with
+	// per-replica PVCs, so pulled model weights survive pod restarts.
+	WorkloadStrategyStatefulSet WorkloadStrategyType = "StatefulSet"
+)
+
+// ModelConditionType is a valid value for ModelStatusCondition.Type
+type ModelConditionType string
+
+const (
+	// ModelProgressing means the Model is reconciling towards its desired state.
+	ModelProgressing ModelConditionType = "Progressing"
+	// ModelAvailable means the Model's backing workload (Deployment or StatefulSet) and Service are ready.
+	ModelAvailable ModelConditionType = "Available"
+)
+
+// ModelStatusCondition describes the state of a Model at a certain point.
+type ModelStatusCondition struct {
+	// Type of the condition.
+	Type ModelConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastUpdateTime is the last time this condition was updated.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a brief machine readable explanation for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ModelStatus defines the observed state of Model
+type ModelStatus struct {
+	// Conditions represent the latest available observations of the Model's state.
+	// +optional
+	Conditions []ModelStatusCondition `json:"conditions,omitempty"`
+
+	// Replicas is the total number of non-terminated pods targeted by this Model's workload
+	// (Deployment or StatefulSet), or the HPA's current replica count when autoscaling is enabled.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// ReadyReplicas is the number of pods targeted by this Model's workload with a Ready condition.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// AvailableReplicas is the number of available pods targeted by this Model's workload.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// UnavailableReplicas is the number of unavailable pods targeted by this Model's workload.
+	// +optional
+	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
+
+	// PodStatuses mirrors the status of every Pod owned by this Model's workload,
+	// keyed by nothing in particular -- order follows the Kubernetes API list order.
+	// +optional
+	PodStatuses []corev1.PodStatus `json:"podStatuses,omitempty"`
+	// ServiceStatuses mirrors the status of every Service owned by this Model.
+	// +optional
+	ServiceStatuses []corev1.ServiceStatus `json:"serviceStatuses,omitempty"`
+	// PVCStatuses mirrors the status of every PersistentVolumeClaim owned by this Model.
+	// +optional
+	PVCStatuses []corev1.PersistentVolumeClaimStatus `json:"pvcStatuses,omitempty"`
+
+	// ResourceHealth summarizes the aggregated state of every owned resource so
+	// `kubectl get model` can show a single at-a-glance health indicator.
+	// +optional
+	ResourceHealth ResourceHealth `json:"resourceHealth,omitempty"`
+}
+
+// ResourceHealth summarizes the health of the resources a Model owns.
+type ResourceHealth struct {
+	// Healthy is true only when every owned Pod, Service and PVC is ready.
+	// +optional
+	Healthy bool `json:"healthy,omitempty"`
+	// Reason is a brief machine readable explanation when Healthy is false.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable detail of the unhealthy resource, if any.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyReplicas`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Model is the Schema for the models API
+type Model struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ModelSpec   `json:"spec,omitempty"`
+	Status ModelStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ModelList contains a list of Model
+type ModelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Model `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Model{}, &ModelList{})
+}
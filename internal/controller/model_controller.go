@@ -18,14 +18,19 @@ package controller
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ollamav1 "github.com/nekomeowww/ollama-operator/api/v1"
@@ -38,6 +43,11 @@ type ModelReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// RestConfig is the manager's own config, used for in-place updates that
+	// exec into pods. It's threaded through explicitly rather than rebuilt
+	// via rest.InClusterConfig so the controller keeps working under
+	// `make run` against a remote cluster and under envtest.
+	RestConfig *rest.Config
 }
 
 //+kubebuilder:rbac:groups=ollama.ayaka.io,resources=models,verbs=get;list;watch;create;update;patch;delete
@@ -45,6 +55,7 @@ type ModelReconciler struct {
 //+kubebuilder:rbac:groups=ollama.ayaka.io,resources=models/finalizers,verbs=update
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=storageclasses,verbs=get;list;watch
@@ -114,28 +125,30 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
 	}
 
-	deployment, err := model.EnsureDeploymentCreated(ctx, r.Client, req.Namespace, req.Name, m.Spec.Image, m.Spec.Replicas, &m, modelRecorder)
+	strategy := model.StrategyFor(&m)
+
+	workload, err := strategy.EnsureCreated(ctx, r.Client, req.Namespace, req.Name, m.Spec.Image, m.Spec.Replicas, &m, modelRecorder)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	modelDeploymentUpdated, err := model.UpdateDeployment(ctx, r.Client, &m, modelRecorder)
+	modelWorkloadUpdated, err := model.ReconcileWorkload(ctx, r.Client, r.RestConfig, &m, modelRecorder)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	if modelDeploymentUpdated {
+	if modelWorkloadUpdated {
 		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
 	}
 
-	modelDeploymentReady, err := model.IsDeploymentReady(ctx, r.Client, req.Namespace, req.Name, modelRecorder)
+	modelWorkloadReady, err := strategy.IsReady(ctx, r.Client, req.Namespace, req.Name, modelRecorder)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	if !modelDeploymentReady {
+	if !modelWorkloadReady {
 		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
 	}
 
-	_, err = model.EnsureServiceCreated(ctx, r.Client, req.Namespace, req.Name, deployment, modelRecorder)
+	_, err = model.EnsureServiceCreated(ctx, r.Client, req.Namespace, req.Name, workload, modelRecorder)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -148,8 +161,26 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
 	}
 
-	if r.ShouldSetReplicas(ctx, m, deployment.Status.Replicas, deployment.Status.ReadyReplicas, deployment.Status.AvailableReplicas, deployment.Status.UnavailableReplicas) {
-		hasSet, err := r.SetReplicas(ctx, m, deployment.Status.Replicas, deployment.Status.ReadyReplicas, deployment.Status.AvailableReplicas, deployment.Status.UnavailableReplicas)
+	hpa, err := model.EnsureHPACreated(ctx, r.Client, req.Namespace, req.Name, workload, &m, modelRecorder)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	hpaUpdated, err := model.UpdateHPA(ctx, r.Client, &m, modelRecorder)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if hpaUpdated {
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
+	}
+
+	replicas := workload.Replicas
+	if hpa != nil {
+		replicas = hpa.Status.CurrentReplicas
+	}
+
+	if r.ShouldSetReplicas(ctx, m, replicas, workload.ReadyReplicas, workload.AvailableReplicas, workload.UnavailableReplicas) {
+		hasSet, err := r.SetReplicas(ctx, m, replicas, workload.ReadyReplicas, workload.AvailableReplicas, workload.UnavailableReplicas)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
@@ -158,6 +189,19 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 	}
 
+	ownedState, err := model.CollectOwnedResources(ctx, r.Client, &m)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	statusUpdated, err := model.ReconcileStatus(ctx, r.Client, &m, ownedState)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if statusUpdated {
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
+	}
+
 	_, err = r.SetAvailable(ctx, m)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -170,11 +214,34 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.RestConfig == nil {
+		r.RestConfig = mgr.GetConfig()
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ollamav1.Model{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.findModelForPod)).
 		Complete(r)
 }
 
+// findModelForPod maps a Pod labeled app=ollama-model-<name> back to the
+// Model reconcile.Request that owns it, so pod churn (crash-looping,
+// image-pull stalls) requeues the Model rather than waiting for the next
+// periodic resync.
+func (r *ModelReconciler) findModelForPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	name, ok := strings.CutPrefix(obj.GetLabels()["app"], "ollama-model-")
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: obj.GetNamespace(), Name: name}}}
+}
+
 func (r *ModelReconciler) IsProgressing(ctx context.Context, ollamaModelResource ollamav1.Model) bool {
 	return len(lo.Filter(ollamaModelResource.Status.Conditions, func(item ollamav1.ModelStatusCondition, _ int) bool {
 		return item.Type == ollamav1.ModelProgressing
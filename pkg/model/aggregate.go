@@ -0,0 +1,162 @@
+package model
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ollamav1 "github.com/nekomeowww/ollama-operator/api/v1"
+	"github.com/nekomeowww/ollama-operator/pkg/model/readiness"
+)
+
+// OwnedState is the set of resources owned by a Model, keyed by the
+// `app=ollama-model-<name>` label selector produced by ModelAppName.
+type OwnedState struct {
+	Pods      []corev1.Pod
+	Services  []corev1.Service
+	Endpoints []corev1.Endpoints
+	PVCs      []corev1.PersistentVolumeClaim
+}
+
+// CollectOwnedResources lists every Pod, Service, Endpoints and
+// PersistentVolumeClaim owned by model, so ReconcileStatus can mirror their
+// state onto Model.Status without the reconciler having to know about each
+// kind. Endpoints are collected alongside Services so resourceHealth can
+// judge Service readiness the same way readiness.ReadyChecker does, without
+// an extra round-trip per Service.
+func CollectOwnedResources(ctx context.Context, c client.Client, model *ollamav1.Model) (*OwnedState, error) {
+	selector := client.MatchingLabels{"app": ModelAppName(model.Name)}
+
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.InNamespace(model.Namespace), selector); err != nil {
+		return nil, err
+	}
+
+	var services corev1.ServiceList
+	if err := c.List(ctx, &services, client.InNamespace(model.Namespace), selector); err != nil {
+		return nil, err
+	}
+
+	var endpoints corev1.EndpointsList
+	if err := c.List(ctx, &endpoints, client.InNamespace(model.Namespace), selector); err != nil {
+		return nil, err
+	}
+
+	var pvcs corev1.PersistentVolumeClaimList
+	if err := c.List(ctx, &pvcs, client.InNamespace(model.Namespace), selector); err != nil {
+		return nil, err
+	}
+
+	return &OwnedState{Pods: pods.Items, Services: services.Items, Endpoints: endpoints.Items, PVCs: pvcs.Items}, nil
+}
+
+// ReconcileStatus diffs state against model's current status and, if
+// anything changed, patches Model.Status so `kubectl get model -o yaml`
+// shows every backing resource's condition in one place.
+func ReconcileStatus(ctx context.Context, c client.Client, model *ollamav1.Model, state *OwnedState) (bool, error) {
+	podStatuses := make([]corev1.PodStatus, 0, len(state.Pods))
+	for _, pod := range state.Pods {
+		podStatuses = append(podStatuses, pod.Status)
+	}
+
+	serviceStatuses := make([]corev1.ServiceStatus, 0, len(state.Services))
+	for _, svc := range state.Services {
+		serviceStatuses = append(serviceStatuses, svc.Status)
+	}
+
+	pvcStatuses := make([]corev1.PersistentVolumeClaimStatus, 0, len(state.PVCs))
+	for _, pvc := range state.PVCs {
+		pvcStatuses = append(pvcStatuses, pvc.Status)
+	}
+
+	health := resourceHealth(state)
+
+	if statusEqual(model.Status, podStatuses, serviceStatuses, pvcStatuses, health) {
+		return false, nil
+	}
+
+	model.Status.PodStatuses = podStatuses
+	model.Status.ServiceStatuses = serviceStatuses
+	model.Status.PVCStatuses = pvcStatuses
+	model.Status.ResourceHealth = health
+
+	err := c.Status().Update(ctx, model)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// resourceHealth reports the first unhealthy resource it finds, in
+// pod/service/PVC priority order, since a failing pod is usually the most
+// actionable signal. Service and PVC readiness are judged with the same
+// rules readiness.ReadyChecker applies, rather than re-deriving them here,
+// so the two can't drift apart.
+func resourceHealth(state *OwnedState) ollamav1.ResourceHealth {
+	for _, pod := range state.Pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if !status.Ready && status.State.Waiting != nil {
+				return ollamav1.ResourceHealth{
+					Healthy: false,
+					Reason:  status.State.Waiting.Reason,
+					Message: status.State.Waiting.Message,
+				}
+			}
+		}
+	}
+
+	for _, svc := range state.Services {
+		endpoints := endpointsFor(state.Endpoints, svc.Name)
+		if !readiness.ServiceReady(&svc, endpoints) {
+			return ollamav1.ResourceHealth{
+				Healthy: false,
+				Reason:  string(readiness.ReasonForServiceReady(&svc, endpoints)),
+				Message: "Service " + svc.Name + " has no ready endpoints",
+			}
+		}
+	}
+
+	for _, pvc := range state.PVCs {
+		if !readiness.PVCReady(&pvc) {
+			return ollamav1.ResourceHealth{
+				Healthy: false,
+				Reason:  string(readiness.ReasonForPVCReady(false)),
+				Message: "PersistentVolumeClaim " + pvc.Name + " is " + string(pvc.Status.Phase),
+			}
+		}
+	}
+
+	return ollamav1.ResourceHealth{Healthy: true}
+}
+
+// endpointsFor returns the Endpoints object named name from endpointsList,
+// or an empty Endpoints if none was collected (e.g. a headless Service,
+// which never gets address subsets populated the same way).
+func endpointsFor(endpointsList []corev1.Endpoints, name string) *corev1.Endpoints {
+	for i := range endpointsList {
+		if endpointsList[i].Name == name {
+			return &endpointsList[i]
+		}
+	}
+
+	return &corev1.Endpoints{}
+}
+
+func statusEqual(
+	status ollamav1.ModelStatus,
+	podStatuses []corev1.PodStatus,
+	serviceStatuses []corev1.ServiceStatus,
+	pvcStatuses []corev1.PersistentVolumeClaimStatus,
+	health ollamav1.ResourceHealth,
+) bool {
+	if !apiequality.Semantic.DeepEqual(status.PodStatuses, podStatuses) ||
+		!apiequality.Semantic.DeepEqual(status.ServiceStatuses, serviceStatuses) ||
+		!apiequality.Semantic.DeepEqual(status.PVCStatuses, pvcStatuses) {
+		return false
+	}
+
+	return status.ResourceHealth == health
+}
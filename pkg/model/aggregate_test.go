@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ollamav1 "github.com/nekomeowww/ollama-operator/api/v1"
+)
+
+func TestStatusEqual_DetectsPodContentChange(t *testing.T) {
+	status := ollamav1.ModelStatus{
+		PodStatuses:    []corev1.PodStatus{{Phase: corev1.PodRunning}},
+		ResourceHealth: ollamav1.ResourceHealth{Healthy: true},
+	}
+
+	changedPods := []corev1.PodStatus{{Phase: corev1.PodPending}}
+
+	if statusEqual(status, changedPods, nil, nil, status.ResourceHealth) {
+		t.Error("statusEqual reported equal despite a pod phase changing, want not-equal")
+	}
+}
+
+func TestStatusEqual_SameLengthDifferentContentService(t *testing.T) {
+	status := ollamav1.ModelStatus{
+		ServiceStatuses: []corev1.ServiceStatus{{LoadBalancer: corev1.LoadBalancerStatus{}}},
+		ResourceHealth:  ollamav1.ResourceHealth{Healthy: true},
+	}
+
+	changedServices := []corev1.ServiceStatus{
+		{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}}},
+	}
+
+	if statusEqual(status, nil, changedServices, nil, status.ResourceHealth) {
+		t.Error("statusEqual reported equal despite service status content changing, want not-equal")
+	}
+}
+
+func TestStatusEqual_TrueWhenNothingChanged(t *testing.T) {
+	status := ollamav1.ModelStatus{
+		PodStatuses:     []corev1.PodStatus{{Phase: corev1.PodRunning}},
+		ServiceStatuses: []corev1.ServiceStatus{{}},
+		PVCStatuses:     []corev1.PersistentVolumeClaimStatus{{Phase: corev1.ClaimBound}},
+		ResourceHealth:  ollamav1.ResourceHealth{Healthy: true},
+	}
+
+	if !statusEqual(status, status.PodStatuses, status.ServiceStatuses, status.PVCStatuses, status.ResourceHealth) {
+		t.Error("statusEqual reported not-equal for identical inputs, want equal")
+	}
+}
+
+func TestStatusEqual_DetectsResourceHealthChange(t *testing.T) {
+	status := ollamav1.ModelStatus{ResourceHealth: ollamav1.ResourceHealth{Healthy: true}}
+
+	unhealthy := ollamav1.ResourceHealth{Healthy: false, Reason: "WaitingForPVC"}
+
+	if statusEqual(status, nil, nil, nil, unhealthy) {
+		t.Error("statusEqual reported equal despite ResourceHealth changing, want not-equal")
+	}
+}
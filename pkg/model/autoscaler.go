@@ -0,0 +1,207 @@
+package model
+
+import (
+	"context"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/samber/lo"
+
+	ollamav1 "github.com/nekomeowww/ollama-operator/api/v1"
+)
+
+// defaultTargetConcurrentRequests is used when Spec.Autoscaling.TargetConcurrentRequests is unset.
+const defaultTargetConcurrentRequests = 10
+
+// ollamaActiveRequestsMetric is the default custom metric the HPA scales
+// on, published by the metrics-exporter sidecar scraping Ollama's
+// /api/ps endpoint.
+const ollamaActiveRequestsMetric = "ollama_active_requests"
+
+// IsAutoscalingEnabled reports whether model has opted into HPA-driven scaling.
+func IsAutoscalingEnabled(model *ollamav1.Model) bool {
+	return model.Spec.Autoscaling != nil
+}
+
+func getHPA(ctx context.Context, c client.Client, namespace string, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	var hpa autoscalingv2.HorizontalPodAutoscaler
+
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ModelAppName(name)}, &hpa)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &hpa, nil
+}
+
+// EnsureHPACreated creates a HorizontalPodAutoscaler owned by the Model's
+// workload when Spec.Autoscaling is set, scaling on the Ollama inference
+// queue metric published by the metrics-exporter sidecar. It is a no-op,
+// returning (nil, nil), when autoscaling is not enabled for model.
+func EnsureHPACreated(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	name string,
+	workload *WorkloadStatus,
+	model *ollamav1.Model,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	if !IsAutoscalingEnabled(model) {
+		return nil, nil
+	}
+
+	hpa, err := getHPA(ctx, c, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if hpa != nil {
+		return hpa, nil
+	}
+
+	hpa = &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+			Name:        ModelAppName(name),
+			Namespace:   namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         workload.APIVersion,
+				Kind:               workload.Kind,
+				Name:               workload.Name,
+				UID:                workload.UID,
+				BlockOwnerDeletion: lo.ToPtr(true),
+			}},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: workload.APIVersion,
+				Kind:       workload.Kind,
+				Name:       workload.Name,
+			},
+			MinReplicas: lo.ToPtr(minReplicas(model.Spec.Autoscaling)),
+			MaxReplicas: model.Spec.Autoscaling.MaxReplicas,
+			Metrics:     buildMetrics(model.Spec.Autoscaling),
+		},
+	}
+
+	err = c.Create(ctx, hpa)
+	if err != nil {
+		return nil, err
+	}
+
+	modelRecorder.Eventf(corev1.EventTypeNormal, "HPACreated", "HorizontalPodAutoscaler %s created", hpa.Name)
+
+	return hpa, nil
+}
+
+// UpdateHPA reconciles an existing HPA's bounds and target metric against
+// Spec.Autoscaling, returning true if an update was made.
+func UpdateHPA(
+	ctx context.Context,
+	c client.Client,
+	model *ollamav1.Model,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (bool, error) {
+	if !IsAutoscalingEnabled(model) {
+		return false, nil
+	}
+
+	hpa, err := getHPA(ctx, c, model.Namespace, model.Name)
+	if err != nil {
+		return false, err
+	}
+	if hpa == nil {
+		return false, nil
+	}
+
+	desiredMin := minReplicas(model.Spec.Autoscaling)
+	desiredMax := model.Spec.Autoscaling.MaxReplicas
+	desiredMetrics := buildMetrics(model.Spec.Autoscaling)
+
+	boundsUnchanged := hpa.Spec.MinReplicas != nil && *hpa.Spec.MinReplicas == desiredMin && hpa.Spec.MaxReplicas == desiredMax
+	metricsUnchanged := apiequality.Semantic.DeepEqual(hpa.Spec.Metrics, desiredMetrics)
+	if boundsUnchanged && metricsUnchanged {
+		return false, nil
+	}
+
+	hpa.Spec.MinReplicas = lo.ToPtr(desiredMin)
+	hpa.Spec.MaxReplicas = desiredMax
+	hpa.Spec.Metrics = desiredMetrics
+
+	err = c.Update(ctx, hpa)
+	if err != nil {
+		return false, err
+	}
+
+	modelRecorder.Eventf(corev1.EventTypeNormal, "HPAUpdated", "HorizontalPodAutoscaler %s bounds updated to [%d, %d]", hpa.Name, desiredMin, desiredMax)
+
+	return true, nil
+}
+
+// buildMetrics builds the Pods metric source scaling on Ollama's own
+// inference queue metric, as published by the metrics-exporter sidecar.
+func buildMetrics(spec *ollamav1.AutoscalingSpec) []autoscalingv2.MetricSpec {
+	return []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: metricName(spec)},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: lo.ToPtr(*resource.NewQuantity(int64(targetConcurrentRequests(spec)), resource.DecimalSI)),
+				},
+			},
+		},
+	}
+}
+
+func minReplicas(spec *ollamav1.AutoscalingSpec) int32 {
+	if spec.MinReplicas != nil {
+		return *spec.MinReplicas
+	}
+
+	return 1
+}
+
+func targetConcurrentRequests(spec *ollamav1.AutoscalingSpec) int32 {
+	if spec.TargetConcurrentRequests != nil {
+		return *spec.TargetConcurrentRequests
+	}
+
+	return defaultTargetConcurrentRequests
+}
+
+func metricName(spec *ollamav1.AutoscalingSpec) string {
+	if spec.MetricName != "" {
+		return spec.MetricName
+	}
+
+	return ollamaActiveRequestsMetric
+}
+
+// NewOllamaMetricsExporterContainer builds the sidecar that scrapes
+// Ollama's /api/ps endpoint and republishes ollama_active_requests and
+// ollama_queue_depth as pod metrics for the HPA's Pods metric source.
+func NewOllamaMetricsExporterContainer() corev1.Container {
+	return corev1.Container{
+		Name:  "ollama-metrics-exporter",
+		Image: "ghcr.io/nekomeowww/ollama-operator-metrics-exporter:latest",
+		Env: []corev1.EnvVar{
+			{Name: "OLLAMA_API_URL", Value: "http://localhost:11434"},
+		},
+		Ports: []corev1.ContainerPort{
+			{Name: "metrics", ContainerPort: 9090},
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ExecOllamaPull execs `ollama pull <image>` inside pod's server container,
+// used by the in-place update path to refresh a running pod's model
+// weights without recreating it. It shells out through the same
+// client-go remotecommand machinery `kubectl exec` uses. config is the
+// manager's own *rest.Config rather than one discovered via
+// rest.InClusterConfig, so this also works against `make run` pointed at a
+// remote cluster and under envtest.
+func ExecOllamaPull(ctx context.Context, config *rest.Config, pod *corev1.Pod, image string) error {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("building clientset for exec: %w", err)
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: "ollama",
+			Command:   []string{"ollama", "pull", image},
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("building exec stream for pod %s: %w", pod.Name, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("ollama pull %s on pod %s failed: %w: %s", image, pod.Name, err, stderr.String())
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/samber/lo"
 
 	ollamav1 "github.com/nekomeowww/ollama-operator/api/v1"
+	"github.com/nekomeowww/ollama-operator/pkg/model/readiness"
 )
 
 func ModelAppName(name string) string {
@@ -54,6 +55,13 @@ func EnsureDeploymentCreated(
 		return deployment, nil
 	}
 
+	serverContainer := NewOllamaServerContainer(true)
+
+	containers := []corev1.Container{serverContainer}
+	if IsAutoscalingEnabled(model) {
+		containers = append(containers, NewOllamaMetricsExporterContainer())
+	}
+
 	deployment = &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Labels:      map[string]string{},
@@ -85,9 +93,7 @@ func EnsureDeploymentCreated(
 					InitContainers: []corev1.Container{
 						NewOllamaPullerContainer(image, namespace),
 					},
-					Containers: []corev1.Container{
-						NewOllamaServerContainer(true),
-					},
+					Containers: containers,
 					Volumes: []corev1.Volume{
 						{
 							Name: "image-storage",
@@ -104,6 +110,8 @@ func EnsureDeploymentCreated(
 		},
 	}
 
+	applyScheduling(&model.Spec, &deployment.Spec.Template.Spec.Containers[0], &deployment.Spec.Template.Spec)
+
 	err = c.Create(ctx, deployment)
 	if err != nil {
 		return nil, err
@@ -131,17 +139,17 @@ func IsDeploymentReady(
 		return false, nil
 	}
 
-	replica := 1
-	if deployment.Spec.Replicas != nil {
-		replica = int(*deployment.Spec.Replicas)
+	ready, reason, err := readiness.NewReadyChecker(c).IsReady(ctx, deployment)
+	if err != nil {
+		return false, err
 	}
-	if deployment.Status.ReadyReplicas == int32(replica) {
+	if ready {
 		log.Info("deployment is ready", "deployment", deployment)
 		return true, nil
 	}
 
-	log.Info("waiting for deployment to be ready", "deployment", deployment)
-	modelRecorder.Eventf("Normal", "WaitingForDeployment", "Waiting for deployment %s to become ready", deployment.Name)
+	log.Info("waiting for deployment to be ready", "deployment", deployment, "reason", reason)
+	modelRecorder.Eventf("Normal", string(reason), "Waiting for deployment %s to become ready", deployment.Name)
 
 	return false, nil
 }
@@ -205,7 +213,7 @@ func EnsureServiceCreated(
 	c client.Client,
 	namespace string,
 	name string,
-	deployment *appsv1.Deployment,
+	workload *WorkloadStatus,
 	modelRecorder *WrappedRecorder[*ollamav1.Model],
 ) (*corev1.Service, error) {
 	service, err := getService(ctx, c, namespace, name)
@@ -223,10 +231,10 @@ func EnsureServiceCreated(
 			Name:        ModelAppName(name),
 			Namespace:   namespace,
 			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion:         "apps/v1",
-				Kind:               "Deployment",
-				Name:               deployment.Name,
-				UID:                deployment.UID,
+				APIVersion:         workload.APIVersion,
+				Kind:               workload.Kind,
+				Name:               workload.Name,
+				UID:                workload.UID,
 				BlockOwnerDeletion: lo.ToPtr(true),
 			}},
 		},
@@ -245,6 +253,18 @@ func EnsureServiceCreated(
 		},
 	}
 
+	// Carry the app label so CollectOwnedResources finds this Service (and
+	// the Endpoints object that inherits its labels) by label selector.
+	service.Labels["app"] = ModelAppName(name)
+
+	// A StatefulSet's governing service must be headless so each pod gets a
+	// stable DNS identity (pod-0.svc, pod-1.svc, ...) instead of a single
+	// load-balanced ClusterIP, which is the whole point of offering the
+	// StatefulSet workload strategy.
+	if workload.Kind == "StatefulSet" {
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+	}
+
 	err = c.Create(ctx, service)
 	if err != nil {
 		return nil, err
@@ -271,9 +291,14 @@ func IsServiceReady(
 	if service == nil {
 		return false, nil
 	}
-	if service.Spec.ClusterIP == "" {
-		log.Info("waiting for service to have cluster IP", "service", service)
-		modelRecorder.Eventf("Normal", "WaitingForService", "Waiting for service %s to have cluster IP", service.Name)
+
+	ready, reason, err := readiness.NewReadyChecker(c).IsReady(ctx, service)
+	if err != nil {
+		return false, err
+	}
+	if !ready {
+		log.Info("waiting for service to be ready", "service", service, "reason", reason)
+		modelRecorder.Eventf("Normal", string(reason), "Waiting for service %s to become ready", service.Name)
 
 		return false, nil
 	}
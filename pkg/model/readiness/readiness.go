@@ -0,0 +1,333 @@
+// Package readiness implements a Helm 3.5 style ready-checker: a set of
+// per-kind rules for deciding whether a Kubernetes object is actually
+// serving traffic, rather than merely "created". It is intentionally
+// stricter than comparing ReadyReplicas == Replicas, since that comparison
+// hides stuck init containers, pending PVCs, and in-progress rollouts.
+package readiness
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reason is a short machine-readable explanation for why an object is not
+// yet ready. It is suitable for use as a modelRecorder event reason.
+type Reason string
+
+const (
+	ReasonReady                     Reason = ""
+	ReasonWaitingForDeployment      Reason = "WaitingForDeployment"
+	ReasonWaitingForStatefulSet     Reason = "WaitingForStatefulSet"
+	ReasonWaitingForPVC             Reason = "WaitingForPVC"
+	ReasonWaitingForService         Reason = "WaitingForService"
+	ReasonWaitingForInitContainer   Reason = "WaitingForInitContainer"
+	ReasonWaitingForContainer       Reason = "WaitingForContainer"
+	ReasonContainerCrashLoopBackOff Reason = "ContainerCrashLoopBackOff"
+	ReasonRolloutInProgress         Reason = "RolloutInProgress"
+	ReasonUnschedulable             Reason = "Unschedulable"
+)
+
+// ReadyChecker determines whether an object is ready to serve, following
+// the same per-kind rules Helm 3.5 uses for `helm install --wait`.
+type ReadyChecker struct {
+	client client.Client
+}
+
+// NewReadyChecker returns a ReadyChecker backed by c.
+func NewReadyChecker(c client.Client) *ReadyChecker {
+	return &ReadyChecker{client: c}
+}
+
+// IsReady dispatches to the per-kind check for obj and reports both whether
+// the object is ready and, if not, a Reason suitable for surfacing back to
+// the user through a modelRecorder event.
+func (c *ReadyChecker) IsReady(ctx context.Context, obj runtime.Object) (bool, Reason, error) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return c.deploymentReady(ctx, o)
+	case *appsv1.StatefulSet:
+		return c.statefulSetReady(ctx, o)
+	case *corev1.Service:
+		return c.serviceReady(ctx, o)
+	case *corev1.PersistentVolumeClaim:
+		return c.pvcReady(o)
+	case *corev1.Pod:
+		return c.podReady(o)
+	default:
+		return false, "", fmt.Errorf("readiness: unsupported object kind %T", obj)
+	}
+}
+
+// deploymentReady reports a Deployment ready only once the replica set
+// matching the Deployment's current pod template has fully rolled out
+// and every one of its pods is itself ready. This avoids reporting a
+// rollout as ready while old pods are still serving traffic.
+func (c *ReadyChecker) deploymentReady(ctx context.Context, dep *appsv1.Deployment) (bool, Reason, error) {
+	rs, err := c.getNewReplicaSet(ctx, dep)
+	if err != nil {
+		return false, "", err
+	}
+	if rs == nil {
+		return false, ReasonRolloutInProgress, nil
+	}
+
+	expectedReplicas := int32(1)
+	if dep.Spec.Replicas != nil {
+		expectedReplicas = *dep.Spec.Replicas
+	}
+	if rs.Status.ReadyReplicas < expectedReplicas {
+		return false, ReasonRolloutInProgress, nil
+	}
+	if dep.Status.UpdatedReplicas < expectedReplicas || dep.Status.ReadyReplicas < expectedReplicas {
+		return false, ReasonRolloutInProgress, nil
+	}
+
+	ready, reason, err := c.podsReadyForObject(ctx, dep.Namespace, dep.Spec.Selector)
+	if err != nil || !ready {
+		return ready, reason, err
+	}
+
+	return true, ReasonReady, nil
+}
+
+// getNewReplicaSet finds the ReplicaSet owned by dep whose pod template,
+// with the pod-template-hash label stripped, equals dep's current pod
+// template, mirroring kubectl's GetNewReplicaSet. The label's value itself
+// is not compared. It returns nil, nil when the new ReplicaSet
+// has not appeared yet. A ReplicaSet without a pod-template-hash label is
+// never considered a match, so a mid-rollout RS missing the label can't be
+// mistaken for the new one.
+func (c *ReadyChecker) getNewReplicaSet(ctx context.Context, dep *appsv1.Deployment) (*appsv1.ReplicaSet, error) {
+	var rsList appsv1.ReplicaSetList
+
+	err := c.client.List(ctx, &rsList, client.InNamespace(dep.Namespace), client.MatchingLabels(dep.Spec.Selector.MatchLabels))
+	if err != nil {
+		return nil, err
+	}
+
+	wantTemplate := stripPodTemplateHash(dep.Spec.Template)
+
+	for i := range rsList.Items {
+		rs := &rsList.Items[i]
+		if !isOwnedBy(rs.OwnerReferences, dep.UID) {
+			continue
+		}
+		if rs.Labels["pod-template-hash"] == "" {
+			continue
+		}
+		if apiequality.Semantic.DeepEqual(stripPodTemplateHash(rs.Spec.Template), wantTemplate) {
+			return rs, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// stripPodTemplateHash returns a copy of template with the pod-template-hash
+// label removed, so a Deployment's own template (which never carries the
+// label) can be compared against a ReplicaSet's template (which does).
+func stripPodTemplateHash(template corev1.PodTemplateSpec) corev1.PodTemplateSpec {
+	if _, ok := template.Labels["pod-template-hash"]; !ok {
+		return template
+	}
+
+	out := *template.DeepCopy()
+	delete(out.Labels, "pod-template-hash")
+
+	return out
+}
+
+func isOwnedBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// podsReadyForObject lists pods matching selector in namespace and reports
+// ready only once every pod is individually ready.
+func (c *ReadyChecker) podsReadyForObject(ctx context.Context, namespace string, selector *metav1.LabelSelector) (bool, Reason, error) {
+	var podList corev1.PodList
+
+	err := c.client.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabels(selector.MatchLabels))
+	if err != nil {
+		return false, "", err
+	}
+	if len(podList.Items) == 0 {
+		return false, ReasonWaitingForInitContainer, nil
+	}
+
+	for i := range podList.Items {
+		ready, reason, err := c.podReady(&podList.Items[i])
+		if err != nil || !ready {
+			return ready, reason, err
+		}
+	}
+
+	return true, ReasonReady, nil
+}
+
+// podReady reports a Pod ready only once every init container has
+// completed and every regular container is both running and passing its
+// readiness probe.
+func (c *ReadyChecker) podReady(pod *corev1.Pod) (bool, Reason, error) {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason == "Unschedulable" {
+			return false, ReasonUnschedulable, nil
+		}
+	}
+
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.ExitCode == 0 {
+			continue
+		}
+
+		return false, ReasonWaitingForInitContainer, nil
+	}
+
+	return containersReady(pod.Status.ContainerStatuses)
+}
+
+// containersReady reports ready once every container status is Ready, and
+// surfaces a CrashLoopBackOff reason distinctly from a plain "still
+// starting" wait so it is obvious in `kubectl describe model` which one is
+// happening.
+func containersReady(statuses []corev1.ContainerStatus) (bool, Reason, error) {
+	for _, status := range statuses {
+		if status.Ready {
+			continue
+		}
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+			return false, ReasonContainerCrashLoopBackOff, nil
+		}
+
+		return false, ReasonWaitingForContainer, nil
+	}
+
+	return true, ReasonReady, nil
+}
+
+// serviceReady reports a ClusterIP Service ready once it has been assigned
+// a cluster IP and, unless it is headless, has at least one endpoint
+// address backing it.
+func (c *ReadyChecker) serviceReady(ctx context.Context, svc *corev1.Service) (bool, Reason, error) {
+	if svc.Spec.ClusterIP == "" {
+		return false, ReasonWaitingForService, nil
+	}
+	if svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return true, ReasonReady, nil
+	}
+
+	var endpoints corev1.Endpoints
+
+	err := c.client.Get(ctx, client.ObjectKey{Namespace: svc.Namespace, Name: svc.Name}, &endpoints)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, ReasonWaitingForService, nil
+		}
+
+		return false, "", err
+	}
+
+	return ServiceReady(svc, &endpoints), ReasonForServiceReady(svc, &endpoints), nil
+}
+
+// ServiceReady reports whether svc is ready given its associated endpoints,
+// by the same rule serviceReady applies when it fetches endpoints itself:
+// a ClusterIP Service needs an assigned cluster IP and, unless headless, at
+// least one endpoint address; a headless Service is ready as soon as it
+// exists. It is exported so callers that already have both objects on hand,
+// like aggregate.resourceHealth, don't have to re-derive this logic.
+func ServiceReady(svc *corev1.Service, endpoints *corev1.Endpoints) bool {
+	if svc.Spec.ClusterIP == "" {
+		return false
+	}
+	if svc.Spec.ClusterIP == corev1.ClusterIPNone {
+		return true
+	}
+
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReasonForServiceReady mirrors ServiceReady but returns the Reason to
+// surface when the Service is not ready.
+func ReasonForServiceReady(svc *corev1.Service, endpoints *corev1.Endpoints) Reason {
+	if ServiceReady(svc, endpoints) {
+		return ReasonReady
+	}
+
+	return ReasonWaitingForService
+}
+
+// pvcReady reports a PersistentVolumeClaim ready once it has been bound to
+// a PersistentVolume.
+func (c *ReadyChecker) pvcReady(pvc *corev1.PersistentVolumeClaim) (bool, Reason, error) {
+	ready := PVCReady(pvc)
+
+	return ready, ReasonForPVCReady(ready), nil
+}
+
+// PVCReady reports whether pvc has been bound to a PersistentVolume. It is
+// exported so callers that already have the PVC on hand, like
+// aggregate.resourceHealth, can reuse this check instead of re-deriving it.
+func PVCReady(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Status.Phase == corev1.ClaimBound
+}
+
+// ReasonForPVCReady mirrors PVCReady but returns the Reason to surface when
+// the PVC is not ready.
+func ReasonForPVCReady(ready bool) Reason {
+	if ready {
+		return ReasonReady
+	}
+
+	return ReasonWaitingForPVC
+}
+
+// statefulSetReady reports a StatefulSet ready using the same rolling
+// update partition logic Helm checks: the replicas above the partition
+// must be ready, and the current and update revisions must match so a
+// partitioned rollout in progress isn't reported as finished.
+func (c *ReadyChecker) statefulSetReady(ctx context.Context, sts *appsv1.StatefulSet) (bool, Reason, error) {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+
+	var partition int32
+	if sts.Spec.UpdateStrategy.RollingUpdate != nil && sts.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		partition = *sts.Spec.UpdateStrategy.RollingUpdate.Partition
+	}
+
+	if sts.Status.ReadyReplicas < replicas-partition {
+		return false, ReasonWaitingForStatefulSet, nil
+	}
+	if sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+		return false, ReasonRolloutInProgress, nil
+	}
+
+	ready, reason, err := c.podsReadyForObject(ctx, sts.Namespace, sts.Spec.Selector)
+	if err != nil || !ready {
+		return ready, reason, err
+	}
+
+	return true, ReasonReady, nil
+}
@@ -0,0 +1,155 @@
+package readiness
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetNewReplicaSet_MatchesOnPodTemplateHash(t *testing.T) {
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default", UID: types.UID("dep-uid")},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "demo"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "demo", Image: "ollama:new"}}},
+			},
+		},
+	}
+
+	stale := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "demo-stale", Namespace: "default",
+			Labels:          map[string]string{"app": "demo", "pod-template-hash": "stale"},
+			OwnerReferences: []metav1.OwnerReference{{UID: dep.UID}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "demo", "pod-template-hash": "stale"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "demo", Image: "ollama:old"}}},
+			},
+		},
+	}
+	current := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "demo-current", Namespace: "default",
+			Labels:          map[string]string{"app": "demo", "pod-template-hash": "current"},
+			OwnerReferences: []metav1.OwnerReference{{UID: dep.UID}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "demo", "pod-template-hash": "current"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "demo", Image: "ollama:new"}}},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(dep, stale, current).Build()
+
+	checker := NewReadyChecker(c)
+
+	rs, err := checker.getNewReplicaSet(context.Background(), dep)
+	if err != nil {
+		t.Fatalf("getNewReplicaSet returned error: %v", err)
+	}
+	if rs == nil {
+		t.Fatal("getNewReplicaSet returned nil, want the current ReplicaSet")
+	}
+	if rs.Name != "demo-current" {
+		t.Fatalf("getNewReplicaSet matched %q, want %q", rs.Name, "demo-current")
+	}
+}
+
+func TestGetNewReplicaSet_NoMatchReturnsNil(t *testing.T) {
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default", UID: types.UID("dep-uid")},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "demo"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "demo", Image: "ollama:new"}}},
+			},
+		},
+	}
+	stale := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "demo-stale", Namespace: "default",
+			Labels:          map[string]string{"app": "demo", "pod-template-hash": "stale"},
+			OwnerReferences: []metav1.OwnerReference{{UID: dep.UID}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "demo", "pod-template-hash": "stale"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "demo", Image: "ollama:old"}}},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(dep, stale).Build()
+
+	rs, err := NewReadyChecker(c).getNewReplicaSet(context.Background(), dep)
+	if err != nil {
+		t.Fatalf("getNewReplicaSet returned error: %v", err)
+	}
+	if rs != nil {
+		t.Fatalf("getNewReplicaSet matched %q, want no match", rs.Name)
+	}
+}
+
+func TestPvcReady(t *testing.T) {
+	checker := NewReadyChecker(fake.NewClientBuilder().Build())
+
+	bound := &corev1.PersistentVolumeClaim{Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound}}
+	if ready, _, _ := checker.pvcReady(bound); !ready {
+		t.Error("pvcReady(bound) = false, want true")
+	}
+
+	pending := &corev1.PersistentVolumeClaim{Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending}}
+	ready, reason, _ := checker.pvcReady(pending)
+	if ready {
+		t.Error("pvcReady(pending) = true, want false")
+	}
+	if reason != ReasonWaitingForPVC {
+		t.Errorf("pvcReady(pending) reason = %q, want %q", reason, ReasonWaitingForPVC)
+	}
+}
+
+func TestContainersReady_CrashLoopBackOffDistinctFromWaiting(t *testing.T) {
+	crashLooping := []corev1.ContainerStatus{
+		{Ready: false, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+	}
+	ready, reason, err := containersReady(crashLooping)
+	if err != nil {
+		t.Fatalf("containersReady returned error: %v", err)
+	}
+	if ready {
+		t.Error("containersReady(crashLooping) = true, want false")
+	}
+	if reason != ReasonContainerCrashLoopBackOff {
+		t.Errorf("containersReady(crashLooping) reason = %q, want %q", reason, ReasonContainerCrashLoopBackOff)
+	}
+
+	starting := []corev1.ContainerStatus{
+		{Ready: false, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+	}
+	ready, reason, _ = containersReady(starting)
+	if ready {
+		t.Error("containersReady(starting) = true, want false")
+	}
+	if reason != ReasonWaitingForContainer {
+		t.Errorf("containersReady(starting) reason = %q, want %q", reason, ReasonWaitingForContainer)
+	}
+}
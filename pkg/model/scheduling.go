@@ -0,0 +1,72 @@
+package model
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	ollamav1 "github.com/nekomeowww/ollama-operator/api/v1"
+)
+
+// gpuResourceNames maps a GPUSpec vendor to the extended resource name the
+// corresponding device plugin advertises on the node.
+var gpuResourceNames = map[string]corev1.ResourceName{
+	"nvidia": "nvidia.com/gpu",
+	"amd":    "amd.com/gpu",
+	"intel":  "gpu.intel.com/i915",
+}
+
+// applyScheduling copies Resources, NodeSelector, Tolerations, Affinity and
+// RuntimeClassName from spec onto the pod template, and expands Spec.GPU
+// into the matching resource request and a NoSchedule toleration so a
+// model requesting a GPU doesn't land on a CPU-only node and OOM. No node
+// selector is added for Spec.GPU.
+func applyScheduling(spec *ollamav1.ModelSpec, container *corev1.Container, podSpec *corev1.PodSpec) {
+	// Deep-copy everything we might mutate below (GPU expansion writes into
+	// Resources.Limits/Requests and Tolerations) so we never alias, and
+	// thereby mutate, model.Spec itself.
+	container.Resources = *spec.Resources.DeepCopy()
+
+	podSpec.NodeSelector = make(map[string]string, len(spec.NodeSelector))
+	for k, v := range spec.NodeSelector {
+		podSpec.NodeSelector[k] = v
+	}
+	podSpec.Tolerations = append([]corev1.Toleration(nil), spec.Tolerations...)
+	podSpec.Affinity = spec.Affinity.DeepCopy()
+	podSpec.RuntimeClassName = spec.RuntimeClassName
+
+	if spec.GPU == nil {
+		return
+	}
+
+	resourceName, ok := gpuResourceNames[spec.GPU.Vendor]
+	if !ok {
+		return
+	}
+
+	count := spec.GPU.Count
+	if count <= 0 {
+		count = 1
+	}
+
+	if container.Resources.Limits == nil {
+		container.Resources.Limits = corev1.ResourceList{}
+	}
+	if container.Resources.Requests == nil {
+		container.Resources.Requests = corev1.ResourceList{}
+	}
+	quantity := *resource.NewQuantity(int64(count), resource.DecimalSI)
+	container.Resources.Limits[resourceName] = quantity
+	container.Resources.Requests[resourceName] = quantity
+
+	// No node selector is added here: device plugins (NVIDIA, AMD, Intel)
+	// advertise resourceName on Node.Status.Capacity, not via a node label,
+	// so the extended resource request above is what actually steers the
+	// scheduler onto a GPU node. Inventing a node-selector label here would
+	// make every GPU request unschedulable on real clusters, since nothing
+	// sets that label.
+	podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+		Key:      string(resourceName),
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	})
+}
@@ -0,0 +1,409 @@
+package model
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/samber/lo"
+
+	ollamav1 "github.com/nekomeowww/ollama-operator/api/v1"
+	"github.com/nekomeowww/ollama-operator/pkg/model/readiness"
+)
+
+// modelRevisionAnnotation records the Ollama model tag that was last pulled
+// in place, so a later reconcile can tell an in-place pull has already
+// happened without re-execing it every loop.
+const modelRevisionAnnotation = "ollama.ayaka.io/model-revision"
+
+// WorkloadStatus is the subset of a Deployment's or StatefulSet's identity
+// and replica counters that the reconciler needs, independent of which
+// workload kind is actually backing the Model.
+type WorkloadStatus struct {
+	Name       string
+	UID        types.UID
+	APIVersion string
+	Kind       string
+
+	Replicas            int32
+	ReadyReplicas       int32
+	AvailableReplicas   int32
+	UnavailableReplicas int32
+}
+
+// WorkloadStrategy creates, updates and health-checks the workload backing
+// a Model. DeploymentStrategy and StatefulSetStrategy are the two built-in
+// implementations; Spec.WorkloadStrategy selects between them.
+type WorkloadStrategy interface {
+	EnsureCreated(
+		ctx context.Context,
+		c client.Client,
+		namespace string,
+		name string,
+		image string,
+		replicas *int32,
+		model *ollamav1.Model,
+		modelRecorder *WrappedRecorder[*ollamav1.Model],
+	) (*WorkloadStatus, error)
+
+	Update(
+		ctx context.Context,
+		c client.Client,
+		model *ollamav1.Model,
+		modelRecorder *WrappedRecorder[*ollamav1.Model],
+	) (bool, error)
+
+	IsReady(
+		ctx context.Context,
+		c client.Client,
+		namespace string,
+		name string,
+		modelRecorder *WrappedRecorder[*ollamav1.Model],
+	) (bool, error)
+}
+
+// StrategyFor returns the WorkloadStrategy selected by model.Spec.WorkloadStrategy,
+// defaulting to DeploymentStrategy.
+func StrategyFor(model *ollamav1.Model) WorkloadStrategy {
+	switch model.Spec.WorkloadStrategy {
+	case ollamav1.WorkloadStrategyStatefulSet:
+		return &StatefulSetStrategy{}
+	default:
+		return &DeploymentStrategy{}
+	}
+}
+
+// ReconcileWorkload reconciles the Model's desired replica count and image
+// against its current workload, dispatching to the strategy selected by
+// Spec.WorkloadStrategy. It replaces the old Deployment-only UpdateDeployment.
+//
+// The in-place-pull and autoscaling checks are handled once here, ahead of
+// the strategy dispatch, rather than duplicated in each strategy's Update:
+// an in-place pull preempts the regular rolling-update path, and once a
+// HorizontalPodAutoscaler is enabled it owns Replicas, so writing it from
+// here would fight the HPA's own scaling decisions.
+func ReconcileWorkload(
+	ctx context.Context,
+	c client.Client,
+	restConfig *rest.Config,
+	model *ollamav1.Model,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (bool, error) {
+	if handled, err := tryInPlaceUpdate(ctx, c, restConfig, model, modelRecorder); handled || err != nil {
+		return false, err
+	}
+	if IsAutoscalingEnabled(model) {
+		return false, nil
+	}
+
+	return StrategyFor(model).Update(ctx, c, model, modelRecorder)
+}
+
+// DeploymentStrategy is the original workload backend: a single Deployment
+// with a shared read-only image-storage PVC and rolling updates.
+type DeploymentStrategy struct{}
+
+var _ WorkloadStrategy = (*DeploymentStrategy)(nil)
+
+func (s *DeploymentStrategy) EnsureCreated(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	name string,
+	image string,
+	replicas *int32,
+	model *ollamav1.Model,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (*WorkloadStatus, error) {
+	deployment, err := EnsureDeploymentCreated(ctx, c, namespace, name, image, replicas, model, modelRecorder)
+	if err != nil {
+		return nil, err
+	}
+
+	return deploymentWorkloadStatus(deployment), nil
+}
+
+func (s *DeploymentStrategy) Update(
+	ctx context.Context,
+	c client.Client,
+	model *ollamav1.Model,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (bool, error) {
+	return UpdateDeployment(ctx, c, model, modelRecorder)
+}
+
+func (s *DeploymentStrategy) IsReady(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	name string,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (bool, error) {
+	return IsDeploymentReady(ctx, c, namespace, name, modelRecorder)
+}
+
+func deploymentWorkloadStatus(deployment *appsv1.Deployment) *WorkloadStatus {
+	return &WorkloadStatus{
+		Name:                deployment.Name,
+		UID:                 deployment.UID,
+		APIVersion:          "apps/v1",
+		Kind:                "Deployment",
+		Replicas:            deployment.Status.Replicas,
+		ReadyReplicas:       deployment.Status.ReadyReplicas,
+		AvailableReplicas:   deployment.Status.AvailableReplicas,
+		UnavailableReplicas: deployment.Status.UnavailableReplicas,
+	}
+}
+
+// StatefulSetStrategy gives each replica a stable identity and its own
+// per-replica PVC (via a VolumeClaimTemplate), so a multi-GB model image
+// pulled once survives pod restarts instead of being re-pulled by the init
+// container on every replacement.
+type StatefulSetStrategy struct{}
+
+var _ WorkloadStrategy = (*StatefulSetStrategy)(nil)
+
+func getStatefulSet(ctx context.Context, c client.Client, namespace string, name string) (*appsv1.StatefulSet, error) {
+	var statefulSet appsv1.StatefulSet
+
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ModelAppName(name)}, &statefulSet)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &statefulSet, nil
+}
+
+func (s *StatefulSetStrategy) EnsureCreated(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	name string,
+	image string,
+	replicas *int32,
+	model *ollamav1.Model,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (*WorkloadStatus, error) {
+	statefulSet, err := getStatefulSet(ctx, c, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if statefulSet != nil {
+		return statefulSetWorkloadStatus(statefulSet), nil
+	}
+
+	serverContainer := NewOllamaServerContainer(true)
+
+	containers := []corev1.Container{serverContainer}
+	if IsAutoscalingEnabled(model) {
+		containers = append(containers, NewOllamaMetricsExporterContainer())
+	}
+
+	statefulSet = &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+			Name:        ModelAppName(name),
+			Namespace:   namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         model.APIVersion,
+				Kind:               model.Kind,
+				Name:               model.Name,
+				UID:                model.UID,
+				BlockOwnerDeletion: lo.ToPtr(true),
+			}},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    lo.Ternary(replicas == nil, lo.ToPtr(int32(1)), replicas),
+			ServiceName: ModelAppName(name),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": ModelAppName(name),
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app": ModelAppName(name),
+					},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						NewOllamaPullerContainer(image, namespace),
+					},
+					Containers: containers,
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "image-storage",
+						Labels: map[string]string{
+							"app": ModelAppName(name),
+						},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					},
+				},
+			},
+		},
+	}
+
+	applyScheduling(&model.Spec, &statefulSet.Spec.Template.Spec.Containers[0], &statefulSet.Spec.Template.Spec)
+
+	err = c.Create(ctx, statefulSet)
+	if err != nil {
+		return nil, err
+	}
+
+	modelRecorder.Eventf("Normal", "StatefulSetCreated", "StatefulSet %s created", statefulSet.Name)
+
+	return statefulSetWorkloadStatus(statefulSet), nil
+}
+
+func (s *StatefulSetStrategy) Update(
+	ctx context.Context,
+	c client.Client,
+	model *ollamav1.Model,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (bool, error) {
+	statefulSet, err := getStatefulSet(ctx, c, model.Namespace, model.Name)
+	if err != nil {
+		return false, err
+	}
+	if statefulSet == nil {
+		return false, nil
+	}
+
+	replicas := int32(1)
+	if model.Spec.Replicas != nil {
+		replicas = *model.Spec.Replicas
+	}
+	if statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas == replicas {
+		return false, nil
+	}
+
+	statefulSet.Spec.Replicas = lo.ToPtr(replicas)
+
+	err = c.Update(ctx, statefulSet)
+	if err != nil {
+		return false, err
+	}
+
+	modelRecorder.Eventf(corev1.EventTypeNormal, "ModelScaled", "Model scaled from %d to %d", statefulSet.Status.Replicas, replicas)
+
+	return true, nil
+}
+
+func (s *StatefulSetStrategy) IsReady(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	name string,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (bool, error) {
+	statefulSet, err := getStatefulSet(ctx, c, namespace, name)
+	if err != nil {
+		return false, err
+	}
+	if statefulSet == nil {
+		return false, nil
+	}
+
+	ready, reason, err := readiness.NewReadyChecker(c).IsReady(ctx, statefulSet)
+	if err != nil {
+		return false, err
+	}
+	if !ready {
+		modelRecorder.Eventf("Normal", string(reason), "Waiting for statefulset %s to become ready", statefulSet.Name)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func statefulSetWorkloadStatus(statefulSet *appsv1.StatefulSet) *WorkloadStatus {
+	return &WorkloadStatus{
+		Name:                statefulSet.Name,
+		UID:                 statefulSet.UID,
+		APIVersion:          "apps/v1",
+		Kind:                "StatefulSet",
+		Replicas:            statefulSet.Status.Replicas,
+		ReadyReplicas:       statefulSet.Status.ReadyReplicas,
+		AvailableReplicas:   statefulSet.Status.AvailableReplicas,
+		UnavailableReplicas: statefulSet.Status.Replicas - statefulSet.Status.AvailableReplicas,
+	}
+}
+
+// tryInPlaceUpdate implements the KusionStack CollaSet / OpenKruise style
+// in-place update: when Spec.InPlaceUpdate is set and only the served
+// Ollama model tag changed (the pod's container image itself is
+// unchanged), it execs `ollama pull` against the running pods instead of
+// recreating them, and records the pulled tag via modelRevisionAnnotation
+// so the next reconcile is a no-op. It reports handled=true whenever an
+// in-place pull was actually performed against at least one pod, so the
+// caller skips the regular rolling-update path only when there was
+// something to skip it for; if every pod already matches the requested
+// tag, handled is false and replica/rollout reconciliation proceeds as
+// normal.
+func tryInPlaceUpdate(
+	ctx context.Context,
+	c client.Client,
+	restConfig *rest.Config,
+	model *ollamav1.Model,
+	modelRecorder *WrappedRecorder[*ollamav1.Model],
+) (handled bool, err error) {
+	if !model.Spec.InPlaceUpdate {
+		return false, nil
+	}
+
+	var pods corev1.PodList
+
+	err = c.List(ctx, &pods, client.InNamespace(model.Namespace), client.MatchingLabels{"app": ModelAppName(model.Name)})
+	if err != nil {
+		return false, err
+	}
+
+	pulled := false
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Annotations[modelRevisionAnnotation] == model.Spec.Image {
+			continue
+		}
+
+		err = ExecOllamaPull(ctx, restConfig, pod, model.Spec.Image)
+		if err != nil {
+			return false, err
+		}
+
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[modelRevisionAnnotation] = model.Spec.Image
+
+		err = c.Update(ctx, pod)
+		if err != nil {
+			return false, err
+		}
+
+		pulled = true
+	}
+
+	if pulled {
+		modelRecorder.Eventf(corev1.EventTypeNormal, "ModelPulledInPlace", "Pulled model %s in place on existing pods", model.Spec.Image)
+	}
+
+	return pulled, nil
+}
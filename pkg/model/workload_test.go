@@ -0,0 +1,75 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ollamav1 "github.com/nekomeowww/ollama-operator/api/v1"
+)
+
+func TestTryInPlaceUpdate_DisabledIsNoop(t *testing.T) {
+	model := &ollamav1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec:       ollamav1.ModelSpec{InPlaceUpdate: false, Image: "llama3:latest"},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(testScheme(t)).Build()
+
+	handled, err := tryInPlaceUpdate(context.Background(), c, nil, model, nil)
+	if err != nil {
+		t.Fatalf("tryInPlaceUpdate returned error: %v", err)
+	}
+	if handled {
+		t.Error("tryInPlaceUpdate(InPlaceUpdate=false) = true, want false")
+	}
+}
+
+// TestTryInPlaceUpdate_AllPodsAlreadyPulledDoesNotBlockReconciliation guards
+// against the handled=true-no-matter-what regression: once every pod already
+// carries modelRevisionAnnotation for the requested image, nothing was
+// pulled, so the caller must be told handled=false and allowed to continue
+// on to replica/rollout reconciliation.
+func TestTryInPlaceUpdate_AllPodsAlreadyPulledDoesNotBlockReconciliation(t *testing.T) {
+	model := &ollamav1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec:       ollamav1.ModelSpec{InPlaceUpdate: true, Image: "llama3:latest"},
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "demo-0",
+			Namespace:   "default",
+			Labels:      map[string]string{"app": ModelAppName("demo")},
+			Annotations: map[string]string{modelRevisionAnnotation: "llama3:latest"},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(pod).Build()
+
+	handled, err := tryInPlaceUpdate(context.Background(), c, nil, model, nil)
+	if err != nil {
+		t.Fatalf("tryInPlaceUpdate returned error: %v", err)
+	}
+	if handled {
+		t.Error("tryInPlaceUpdate(all pods already pulled) = true, want false so replica/rollout reconciliation still runs")
+	}
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding corev1 to scheme: %v", err)
+	}
+	if err := ollamav1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding ollamav1 to scheme: %v", err)
+	}
+
+	return scheme
+}